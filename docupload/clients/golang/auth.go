@@ -0,0 +1,91 @@
+// ═══════════════════════════════════════════════════════════════════════════════
+// Authentication
+// ═══════════════════════════════════════════════════════════════════════════════
+package docscan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+)
+
+// Authenticator applies credentials to an outgoing request. Implementations
+// must be safe for concurrent use, since a single Client may have many
+// requests in flight at once.
+type Authenticator interface {
+	Apply(req *http.Request) error
+}
+
+// APIKeyAuthenticator sends a static key as X-API-Key, matching the
+// scheme Client uses by default when no Authenticator is configured.
+type APIKeyAuthenticator struct {
+	Key string
+}
+
+// Apply implements Authenticator.
+func (a APIKeyAuthenticator) Apply(req *http.Request) error {
+	req.Header.Set("X-API-Key", a.Key)
+	return nil
+}
+
+// BearerAuthenticator sends a static token as Authorization: Bearer <token>.
+type BearerAuthenticator struct {
+	Token string
+}
+
+// Apply implements Authenticator.
+func (a BearerAuthenticator) Apply(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// CredentialHelperAuthenticator fetches credentials from an external
+// helper binary using the docker-credential-* protocol: it invokes
+// Helper with the argument "get" and writes {"ServerURL": ServerURL} to
+// its stdin, then expects {"Username": "...", "Secret": "..."} on stdout.
+// If the helper returns a Username, credentials are applied as HTTP Basic
+// Auth; otherwise Secret is sent as X-API-Key.
+type CredentialHelperAuthenticator struct {
+	// Helper is the path to (or name of, if on $PATH) the credential
+	// helper binary, e.g. "docker-credential-docscan".
+	Helper string
+
+	// ServerURL identifies which credentials to fetch, typically the
+	// gateway's base URL.
+	ServerURL string
+}
+
+// Apply implements Authenticator.
+func (a CredentialHelperAuthenticator) Apply(req *http.Request) error {
+	var stdin bytes.Buffer
+	if err := json.NewEncoder(&stdin).Encode(struct {
+		ServerURL string `json:"ServerURL"`
+	}{a.ServerURL}); err != nil {
+		return fmt.Errorf("failed to encode credential helper request: %w", err)
+	}
+
+	cmd := exec.CommandContext(req.Context(), a.Helper, "get")
+	cmd.Stdin = &stdin
+
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("credential helper %q failed: %w", a.Helper, err)
+	}
+
+	var creds struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return fmt.Errorf("failed to parse credential helper output: %w", err)
+	}
+
+	if creds.Username != "" {
+		req.SetBasicAuth(creds.Username, creds.Secret)
+	} else {
+		req.Header.Set("X-API-Key", creds.Secret)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+// ═══════════════════════════════════════════════════════════════════════════════
+// Batch / Recursive Upload
+// ═══════════════════════════════════════════════════════════════════════════════
+package docscan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// errBatchAborted marks a BatchItem for a path that was never attempted
+// because FailFast canceled the batch first.
+var errBatchAborted = errors.New("skipped: batch aborted by --fail-fast")
+
+// BatchOptions configures UploadDirectory.
+type BatchOptions struct {
+	// Include, if non-empty, restricts uploads to files whose base name
+	// matches at least one of these filepath.Match glob patterns.
+	Include []string
+
+	// Exclude skips files whose base name matches any of these
+	// filepath.Match glob patterns, even if Include also matches.
+	Exclude []string
+
+	// MaxDepth limits recursion below root. 0 means unlimited; 1 means
+	// only files directly inside root.
+	MaxDepth int
+
+	// Concurrency is the number of upload workers. Defaults to
+	// runtime.NumCPU() if zero or negative.
+	Concurrency int
+
+	// MaxRetries is the number of additional attempts made for a file
+	// whose upload fails with a 429 or 5xx response. Defaults to 3.
+	MaxRetries int
+
+	// FailFast stops submitting new files and cancels in-flight uploads
+	// as soon as one file fails. By default a failed file is recorded in
+	// BatchResult and the rest of the batch continues.
+	FailFast bool
+
+	// ProgressFunc, if set, is called after each file finishes (success
+	// or failure) with the completed item and the running totals.
+	ProgressFunc func(item BatchItem, done, total int)
+}
+
+// BatchItem is the outcome of uploading a single file as part of a batch.
+type BatchItem struct {
+	Path     string
+	Document *Document
+	Err      error
+	Duration time.Duration
+}
+
+// MarshalJSON renders Err as its message string, since error is an
+// interface with no exported fields for encoding/json to see on most
+// dynamic types (e.g. fmt.Errorf/errors.New) — without this, a failed
+// item's reason would silently serialize as {}.
+func (item BatchItem) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Path     string        `json:"path"`
+		Document *Document     `json:"document,omitempty"`
+		Err      string        `json:"err,omitempty"`
+		Duration time.Duration `json:"duration"`
+	}
+	a := alias{Path: item.Path, Document: item.Document, Duration: item.Duration}
+	if item.Err != nil {
+		a.Err = item.Err.Error()
+	}
+	return json.Marshal(a)
+}
+
+// BatchResult is returned by UploadDirectory.
+type BatchResult struct {
+	Items      []BatchItem
+	Succeeded  int
+	Failed     int
+	TotalBytes int64
+	Duration   time.Duration
+}
+
+const defaultBatchMaxRetries = 3
+
+// UploadDirectory walks root (respecting opts.Include/Exclude/MaxDepth),
+// uploads every matching file through a worker pool of opts.Concurrency
+// size, and returns a BatchResult with one BatchItem per file. A single
+// failed file does not abort the batch unless opts.FailFast is set.
+//
+//	result, err := client.UploadDirectory("./receipts", docscan.BatchOptions{
+//	    Include:     []string{"*.jpg", "*.pdf"},
+//	    Concurrency: 8,
+//	})
+func (c *Client) UploadDirectory(root string, opts BatchOptions) (*BatchResult, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	maxRetries := opts.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultBatchMaxRetries
+	}
+
+	paths, err := walkBatchFiles(root, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+
+	start := time.Now()
+	result := &BatchResult{Items: make([]BatchItem, len(paths))}
+	if len(paths) == 0 {
+		result.Duration = time.Since(start)
+		return result, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+
+	worker := func() {
+		defer wg.Done()
+		for i := range jobs {
+			path := paths[i]
+			item := c.uploadBatchFile(ctx, path, maxRetries)
+
+			mu.Lock()
+			result.Items[i] = item
+			done++
+			if item.Err != nil {
+				result.Failed++
+				if opts.FailFast {
+					cancel()
+				}
+			} else {
+				result.Succeeded++
+				if item.Document != nil {
+					result.TotalBytes += item.Document.SizeBytes
+				}
+			}
+			if opts.ProgressFunc != nil {
+				opts.ProgressFunc(item, done, len(paths))
+			}
+			mu.Unlock()
+		}
+	}
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go worker()
+	}
+
+feed:
+	for i := range paths {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	// FailFast can cancel ctx before every path was dequeued from jobs;
+	// mark whatever's left as explicitly skipped rather than leaving
+	// zero-value BatchItems that would be indistinguishable from a
+	// successful empty item in a serialized manifest.
+	for i, item := range result.Items {
+		if item.Path == "" {
+			result.Items[i] = BatchItem{Path: paths[i], Err: errBatchAborted}
+			result.Failed++
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// uploadBatchFile uploads a single file, retrying with exponential backoff
+// on 429/5xx responses and honoring a server-supplied Retry-After.
+func (c *Client) uploadBatchFile(ctx context.Context, path string, maxRetries int) BatchItem {
+	start := time.Now()
+
+	for attempt := 0; ; attempt++ {
+		if ctx.Err() != nil {
+			return BatchItem{Path: path, Err: ctx.Err(), Duration: time.Since(start)}
+		}
+
+		resp, err := c.UploadDocumentWithOptions(path, UploadOptions{Context: ctx})
+		if err == nil {
+			return BatchItem{Path: path, Document: &resp.Document, Duration: time.Since(start)}
+		}
+
+		if attempt >= maxRetries || !isRetryableUploadError(err) {
+			return BatchItem{Path: path, Err: err, Duration: time.Since(start)}
+		}
+
+		wait := jitteredBackoff(attempt, 500*time.Millisecond, 30*time.Second)
+		if apiErr, ok := err.(*APIError); ok && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return BatchItem{Path: path, Err: ctx.Err(), Duration: time.Since(start)}
+		}
+	}
+}
+
+func isRetryableUploadError(err error) bool {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		return false
+	}
+	return apiErr.StatusCode == 429 || apiErr.StatusCode >= 500
+}
+
+// walkBatchFiles collects file paths under root that match opts' include/
+// exclude filters and max depth, in a stable, sorted order.
+func walkBatchFiles(root string, opts BatchOptions) ([]string, error) {
+	var paths []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if opts.MaxDepth > 0 && path != root {
+				// A directory at sepCount(rel) == MaxDepth-1 is itself the
+				// deepest level files may come from, so don't descend into it.
+				if rel, relErr := filepath.Rel(root, path); relErr == nil && sepCount(rel) >= opts.MaxDepth-1 {
+					return fs.SkipDir
+				}
+			}
+			return nil
+		}
+
+		if opts.MaxDepth > 0 {
+			rel, relErr := filepath.Rel(root, path)
+			if relErr == nil && sepCount(rel) >= opts.MaxDepth {
+				return nil
+			}
+		}
+
+		name := filepath.Base(path)
+		if !matchesAny(name, opts.Include) {
+			return nil
+		}
+		if len(opts.Exclude) > 0 && matchesAny(name, opts.Exclude) {
+			return nil
+		}
+
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// sepCount returns the number of path separators in a cleaned relative
+// path, i.e. its depth below root (a direct child of root has depth 0).
+func sepCount(rel string) int {
+	count := 0
+	for _, r := range rel {
+		if r == filepath.Separator {
+			count++
+		}
+	}
+	return count
+}
+
+// matchesAny reports whether name matches any pattern, or true if
+// patterns is empty. Callers using this for an exclude list must guard
+// the empty case themselves (see walkBatchFiles) since "no exclude
+// patterns" should mean "exclude nothing", not "exclude everything".
+func matchesAny(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if ok, err := filepath.Match(p, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package docscan
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWalkBatchFilesMaxDepth(t *testing.T) {
+	root := t.TempDir()
+
+	mustWriteFile(t, filepath.Join(root, "e.txt"))
+	mustWriteFile(t, filepath.Join(root, "a", "b.txt"))
+	mustWriteFile(t, filepath.Join(root, "a", "c", "d.txt"))
+
+	paths, err := walkBatchFiles(root, BatchOptions{MaxDepth: 1})
+	if err != nil {
+		t.Fatalf("walkBatchFiles: %v", err)
+	}
+
+	want := []string{filepath.Join(root, "e.txt")}
+	if !equalStringSlices(paths, want) {
+		t.Fatalf("MaxDepth=1: got %v, want %v", paths, want)
+	}
+}
+
+func TestBatchItemMarshalJSONIncludesErrMessage(t *testing.T) {
+	item := BatchItem{Path: "bad.jpg", Err: errors.New("failed to open file: permission denied")}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	if !strings.Contains(string(data), "permission denied") {
+		t.Fatalf("marshaled item lost the error message: %s", data)
+	}
+}
+
+func TestUploadDirectoryFailFastRecordsEveryPath(t *testing.T) {
+	// Every upload fails with a non-retryable 400, so FailFast cancels
+	// the batch after the first one and some paths are never dequeued.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"BAD_REQUEST","message":"rejected"}}`))
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	var paths []string
+	for i := 0; i < 20; i++ {
+		path := filepath.Join(root, "file"+strconv.Itoa(i)+".txt")
+		mustWriteFile(t, path)
+		paths = append(paths, path)
+	}
+
+	client := NewClient(server.URL, "test-key")
+	result, err := client.UploadDirectory(root, BatchOptions{Concurrency: 1, FailFast: true})
+	if err != nil {
+		t.Fatalf("UploadDirectory: %v", err)
+	}
+
+	if len(result.Items) != len(paths) {
+		t.Fatalf("got %d items, want %d", len(result.Items), len(paths))
+	}
+	if result.Succeeded+result.Failed != len(result.Items) {
+		t.Fatalf("Succeeded(%d)+Failed(%d) != len(Items)(%d)", result.Succeeded, result.Failed, len(result.Items))
+	}
+
+	want := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		want[p] = true
+	}
+	for _, item := range result.Items {
+		if item.Path == "" {
+			t.Fatalf("item has empty Path (zero-value leftover): %+v", item)
+		}
+		if !want[item.Path] {
+			t.Fatalf("item Path %q does not match any input file", item.Path)
+		}
+		if item.Err == nil {
+			t.Fatalf("item for %q has no Err, want a failure or skip reason", item.Path)
+		}
+	}
+}
+
+func mustWriteFile(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -29,15 +29,19 @@
 package docscan
 
 import (
-	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	mathrand "math/rand"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -51,17 +55,90 @@ type Client struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+
+	// Authenticator, if set, applies credentials to every outgoing
+	// request and takes priority over APIKey. See NewClientWithOptions.
+	Authenticator Authenticator
+
+	// RequestIDFunc, if set, supplies the X-Request-Id sent with every
+	// request in place of a freshly generated UUID. Use this to plug in
+	// tracing IDs (e.g. a W3C traceparent) so client errors correlate
+	// with the same ID used elsewhere in a request's trace.
+	RequestIDFunc func() string
 }
 
-// NewClient creates a new DocScan client.
+// NewClient creates a new DocScan client authenticating with a static
+// API key. It is a thin wrapper around NewClientWithOptions for callers
+// who don't need mTLS, Bearer auth, or a credential helper.
 //
 //	client := docscan.NewClient("http://localhost:4000", "your-api-key")
 func NewClient(baseURL, apiKey string) *Client {
+	return NewClientWithOptions(ClientOptions{BaseURL: baseURL, APIKey: apiKey})
+}
+
+// ClientOptions configures NewClientWithOptions.
+type ClientOptions struct {
+	// BaseURL is the DocScan gateway URL, e.g. "http://localhost:4000".
+	BaseURL string
+
+	// APIKey is sent as X-API-Key when Authenticator is nil. Kept
+	// alongside Authenticator for backward compatibility with NewClient.
+	APIKey string
+
+	// Authenticator applies credentials to each outgoing request. If
+	// nil, an APIKeyAuthenticator built from APIKey is used.
+	Authenticator Authenticator
+
+	// TLSConfig, if set, configures the HTTP transport's TLS settings
+	// (client certificate and CA bundle) so the client can talk to
+	// gateways fronted by mTLS.
+	TLSConfig *tls.Config
+
+	// Transport, if set, wraps the client's base *http.Transport so
+	// callers can inject retry/logging/tracing middleware.
+	Transport func(base http.RoundTripper) http.RoundTripper
+
+	// Timeout is the HTTP client timeout. Defaults to 120s.
+	Timeout time.Duration
+
+	// RequestIDFunc, see Client.RequestIDFunc.
+	RequestIDFunc func() string
+}
+
+// NewClientWithOptions creates a DocScan client with full control over
+// authentication and transport.
+//
+//	client := docscan.NewClientWithOptions(docscan.ClientOptions{
+//	    BaseURL:       "https://gateway.internal",
+//	    Authenticator: docscan.BearerAuthenticator{Token: token},
+//	    TLSConfig:     &tls.Config{Certificates: []tls.Certificate{cert}, RootCAs: caPool},
+//	})
+func NewClientWithOptions(opts ClientOptions) *Client {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 120 * time.Second
+	}
+
+	// Clone http.DefaultTransport rather than starting from a bare
+	// &http.Transport{} so plain callers (no TLSConfig/Transport set)
+	// keep proxy-from-environment support and the default dial/handshake/
+	// idle timeouts instead of silently losing them.
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.TLSClientConfig = opts.TLSConfig
+
+	var transport http.RoundTripper = base
+	if opts.Transport != nil {
+		transport = opts.Transport(transport)
+	}
+
 	return &Client{
-		BaseURL: strings.TrimRight(baseURL, "/"),
-		APIKey:  apiKey,
+		BaseURL:       strings.TrimRight(opts.BaseURL, "/"),
+		APIKey:        opts.APIKey,
+		Authenticator: opts.Authenticator,
+		RequestIDFunc: opts.RequestIDFunc,
 		HTTPClient: &http.Client{
-			Timeout: 120 * time.Second,
+			Timeout:   timeout,
+			Transport: transport,
 		},
 	}
 }
@@ -161,6 +238,11 @@ type APIError struct {
 	Code       string `json:"code"`
 	Message    string `json:"message"`
 	RequestID  string `json:"requestId"`
+
+	// RetryAfter is the duration the server asked the caller to wait
+	// before retrying, parsed from the Retry-After header. Zero if the
+	// response carried no such header.
+	RetryAfter time.Duration
 }
 
 func (e *APIError) Error() string {
@@ -178,37 +260,100 @@ func (e *APIError) Error() string {
 //	if err != nil { log.Fatal(err) }
 //	fmt.Printf("OCR applied: %v, characters: %d\n", result.Document.OCR.Applied, result.Document.OCR.CharacterCount)
 func (c *Client) UploadDocument(filePath string) (*UploadResponse, error) {
-	// Open the file
+	return c.UploadDocumentWithOptions(filePath, UploadOptions{})
+}
+
+// UploadOptions configures UploadDocumentWithOptions.
+type UploadOptions struct {
+	// Context governs cancellation of the upload. A cancelled context
+	// aborts the in-flight request, closing the request body with an
+	// error so the server observes a truncated body instead of a hung
+	// connection. Defaults to context.Background() if nil.
+	Context context.Context
+
+	// ProgressFunc, if set, is invoked as the file is streamed with the
+	// cumulative bytes sent and the total file size. Calls are throttled
+	// to roughly minProgressInterval apart so a CLI can render a
+	// percent/speed line without flooding stdout.
+	ProgressFunc func(bytesSent, totalBytes int64)
+
+	// ChunkSize is the buffer size used to stream the file into the
+	// multipart body. Defaults to defaultUploadChunkSize if zero.
+	ChunkSize int
+}
+
+const defaultUploadChunkSize = 32 * 1024
+const minProgressInterval = 100 * time.Millisecond
+
+// UploadDocumentWithOptions uploads a file like UploadDocument, but streams
+// the multipart body through an io.Pipe instead of buffering it in memory,
+// and supports progress reporting and cancellation via UploadOptions. This
+// is the preferred entry point for multi-hundred-MB PDFs.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+//	defer cancel()
+//	result, err := client.UploadDocumentWithOptions(path, docscan.UploadOptions{
+//	    Context: ctx,
+//	    ProgressFunc: func(sent, total int64) {
+//	        fmt.Printf("\r%d / %d bytes", sent, total)
+//	    },
+//	})
+func (c *Client) UploadDocumentWithOptions(filePath string, opts UploadOptions) (*UploadResponse, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultUploadChunkSize
+	}
+
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
-	// Build multipart body
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
-
-	part, err := writer.CreateFormFile("document", filepath.Base(filePath))
+	info, err := file.Stat()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return nil, fmt.Errorf("failed to stat file: %w", err)
 	}
 
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("failed to copy file data: %w", err)
-	}
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
 
-	writer.Close()
+	go func() {
+		part, err := writer.CreateFormFile("document", filepath.Base(filePath))
+		if err != nil {
+			pw.CloseWithError(fmt.Errorf("failed to create form file: %w", err))
+			return
+		}
+
+		src := &progressReader{r: file, ctx: ctx, total: info.Size(), fn: opts.ProgressFunc}
+
+		if _, err := io.CopyBuffer(part, src, make([]byte, chunkSize)); err != nil {
+			pw.CloseWithError(fmt.Errorf("failed to copy file data: %w", err))
+			return
+		}
+
+		if err := writer.Close(); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.Close()
+	}()
 
-	// Create request
-	req, err := http.NewRequest("POST", c.BaseURL+"/v1/documents", &body)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/v1/documents", pr)
 	if err != nil {
+		pr.Close()
 		return nil, err
 	}
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	c.setHeaders(req)
+	if err := c.setHeaders(req); err != nil {
+		pr.Close()
+		return nil, fmt.Errorf("failed to authenticate request: %w", err)
+	}
 
-	// Execute
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
@@ -227,6 +372,44 @@ func (c *Client) UploadDocument(filePath string) (*UploadResponse, error) {
 	return &result, nil
 }
 
+// progressReader wraps an io.Reader, invoking fn with cumulative bytes
+// read at bounded intervals and aborting the read with ctx.Err() once ctx
+// is done, so a cancelled upload unwinds instead of blocking on I/O.
+type progressReader struct {
+	r     io.Reader
+	ctx   context.Context
+	total int64
+	sent  int64
+	fn    func(sent, total int64)
+	last  time.Time
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	select {
+	case <-p.ctx.Done():
+		return 0, p.ctx.Err()
+	default:
+	}
+
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.sent += int64(n)
+	}
+	if p.fn != nil {
+		// A regular file's final EOF typically arrives as its own Read
+		// call returning (0, io.EOF) after the last nonzero-byte read —
+		// check this outside the n > 0 guard so the final callback still
+		// fires even if that last real read landed inside the throttle
+		// window.
+		now := time.Now()
+		if (n > 0 && now.Sub(p.last) >= minProgressInterval) || err == io.EOF {
+			p.last = now
+			p.fn(p.sent, p.total)
+		}
+	}
+	return n, err
+}
+
 // ListDocuments returns all uploaded documents.
 //
 //	docs, err := client.ListDocuments()
@@ -256,9 +439,17 @@ func (c *Client) ListDocuments() (*DocumentListResponse, error) {
 //
 //	err := client.DownloadOriginal("1706900000000-receipt.jpg", "./downloaded-receipt.jpg")
 func (c *Client) DownloadOriginal(documentID, savePath string) error {
+	return c.DownloadOriginalWithOptions(documentID, savePath, DownloadOptions{})
+}
+
+// DownloadOriginalWithOptions saves the original file to disk like
+// DownloadOriginal, with support for resuming a partial download and
+// verifying the result's digest. See DownloadOptions.
+func (c *Client) DownloadOriginalWithOptions(documentID, savePath string, opts DownloadOptions) error {
 	return c.downloadFile(
 		fmt.Sprintf("/v1/documents/%s/download", url.PathEscape(documentID)),
 		savePath,
+		opts,
 	)
 }
 
@@ -266,9 +457,17 @@ func (c *Client) DownloadOriginal(documentID, savePath string) error {
 //
 //	err := client.DownloadText("1706900000000-receipt.jpg", "./receipt.txt")
 func (c *Client) DownloadText(documentID, savePath string) error {
+	return c.DownloadTextWithOptions(documentID, savePath, DownloadOptions{})
+}
+
+// DownloadTextWithOptions saves the extracted text file to disk like
+// DownloadText, with support for resuming a partial download and
+// verifying the result's digest. See DownloadOptions.
+func (c *Client) DownloadTextWithOptions(documentID, savePath string, opts DownloadOptions) error {
 	return c.downloadFile(
 		fmt.Sprintf("/v1/documents/%s/text", url.PathEscape(documentID)),
 		savePath,
+		opts,
 	)
 }
 
@@ -350,10 +549,29 @@ func (c *Client) HealthCheck() (*HealthResponse, error) {
 
 // ─── Internal Helpers ──────────────────────────────────────────────────────
 
-func (c *Client) setHeaders(req *http.Request) {
-	req.Header.Set("X-API-Key", c.APIKey)
-	req.Header.Set("X-Request-Id", generateUUID())
+// setHeaders applies authentication and tracing headers to req. It
+// delegates credentials to c.Authenticator when set, falling back to the
+// legacy static X-API-Key scheme otherwise.
+func (c *Client) setHeaders(req *http.Request) error {
+	if c.Authenticator != nil {
+		if err := c.Authenticator.Apply(req); err != nil {
+			return fmt.Errorf("authenticator failed: %w", err)
+		}
+	} else {
+		req.Header.Set("X-API-Key", c.APIKey)
+	}
+	req.Header.Set("X-Request-Id", c.requestID())
 	req.Header.Set("Accept", "application/json")
+	return nil
+}
+
+// requestID returns the ID to send as X-Request-Id, preferring
+// RequestIDFunc when the caller has set one.
+func (c *Client) requestID() string {
+	if c.RequestIDFunc != nil {
+		return c.RequestIDFunc()
+	}
+	return generateUUID()
 }
 
 func (c *Client) doJSON(method, path string, body io.Reader) (*http.Response, error) {
@@ -361,42 +579,18 @@ func (c *Client) doJSON(method, path string, body io.Reader) (*http.Response, er
 	if err != nil {
 		return nil, err
 	}
-	c.setHeaders(req)
+	if err := c.setHeaders(req); err != nil {
+		return nil, fmt.Errorf("failed to authenticate request: %w", err)
+	}
 	return c.HTTPClient.Do(req)
 }
 
-func (c *Client) downloadFile(urlPath, savePath string) error {
-	req, err := http.NewRequest("GET", c.BaseURL+urlPath, nil)
-	if err != nil {
-		return err
-	}
-	c.setHeaders(req)
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return c.parseError(resp)
-	}
-
-	out, err := os.Create(savePath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer out.Close()
-
-	if _, err := io.Copy(out, resp.Body); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	return nil
-}
+// downloadFile (with resume and digest verification support) is
+// implemented in download.go.
 
 func (c *Client) parseError(resp *http.Response) error {
 	body, _ := io.ReadAll(resp.Body)
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
 
 	var envelope struct {
 		Error struct {
@@ -406,12 +600,23 @@ func (c *Client) parseError(resp *http.Response) error {
 		} `json:"error"`
 	}
 
-	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error.Code != "" {
+	unmarshalErr := json.Unmarshal(body, &envelope)
+
+	requestID := envelope.Error.RequestID
+	if requestID == "" && resp.Request != nil {
+		// The gateway didn't echo a requestId in its error body; fall
+		// back to the ID we sent so callers can still correlate this
+		// error with server-side logs.
+		requestID = resp.Request.Header.Get("X-Request-Id")
+	}
+
+	if unmarshalErr == nil && envelope.Error.Code != "" {
 		return &APIError{
 			StatusCode: resp.StatusCode,
 			Code:       envelope.Error.Code,
 			Message:    envelope.Error.Message,
-			RequestID:  envelope.Error.RequestID,
+			RequestID:  requestID,
+			RetryAfter: retryAfter,
 		}
 	}
 
@@ -419,16 +624,61 @@ func (c *Client) parseError(resp *http.Response) error {
 		StatusCode: resp.StatusCode,
 		Code:       "UNKNOWN",
 		Message:    string(body),
+		RequestID:  requestID,
+		RetryAfter: retryAfter,
 	}
 }
 
+// parseRetryAfter parses a Retry-After header value, which per RFC 9110 is
+// either a number of seconds or an HTTP date. Returns 0 if value is empty
+// or unparseable.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// generateUUID returns a random RFC 4122 version 4 UUID, preferring
+// uuid.NewRandom() and falling back to a manual crypto/rand-based
+// construction if that fails (e.g. its entropy pool isn't readable).
 func generateUUID() string {
-	// Simple UUID v4 using crypto/rand via time-based fallback
-	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
-		time.Now().UnixNano()&0xFFFFFFFF,
-		time.Now().UnixNano()>>32&0xFFFF,
-		0x4000|time.Now().UnixNano()>>48&0x0FFF,
-		0x8000|time.Now().UnixNano()>>60&0x3FFF,
-		time.Now().UnixNano(),
-	)
+	if id, err := uuid.NewRandom(); err == nil {
+		return id.String()
+	}
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand.Read only fails if the OS entropy source itself is
+		// broken; there is nothing better to fall back to at that point.
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x",
+		b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// jitteredBackoff returns an exponential backoff duration with jitter for
+// the given zero-based attempt number, doubling from base and capped at
+// max. Used by both the batch upload retry loop and the event stream
+// reconnect loop so their backoff behavior can't drift apart.
+func jitteredBackoff(attempt int, base, max time.Duration) time.Duration {
+	d := base << attempt
+	if d <= 0 || d > max {
+		d = max
+	}
+	return d/2 + time.Duration(mathrand.Int63n(int64(d/2)+1))
 }
@@ -4,6 +4,7 @@
 //
 // Build:   go build -o docscan-cli ./cmd/main.go
 // Run:     ./docscan-cli upload /path/to/receipt.jpg
+//          ./docscan-cli batch ./receipts --concurrency=8
 //          ./docscan-cli list
 //          ./docscan-cli download <document-id> /path/to/save
 //          ./docscan-cli text <document-id>
@@ -18,9 +19,13 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	docscan "github.com/docupload/docscan-client"
 )
@@ -44,14 +49,35 @@ func main() {
 	// ── Upload ──────────────────────────────────────────────────────────
 	case "upload":
 		if len(os.Args) < 3 {
-			fmt.Println("Usage: docscan-cli upload <file-path>")
+			fmt.Println("Usage: docscan-cli upload <file-path> [--progress|--no-progress]")
 			os.Exit(1)
 		}
 		filePath := os.Args[2]
+		showProgress := true
+		for _, arg := range os.Args[3:] {
+			switch arg {
+			case "--progress":
+				showProgress = true
+			case "--no-progress":
+				showProgress = false
+			}
+		}
+
 		fmt.Printf("Uploading: %s\n", filePath)
 		fmt.Println("─────────────────────────────────────────")
 
-		result, err := client.UploadDocument(filePath)
+		opts := docscan.UploadOptions{Context: context.Background()}
+		if showProgress {
+			start := time.Now()
+			opts.ProgressFunc = func(sent, total int64) {
+				printUploadProgress(sent, total, time.Since(start))
+			}
+		}
+
+		result, err := client.UploadDocumentWithOptions(filePath, opts)
+		if showProgress {
+			fmt.Fprintln(os.Stderr)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -107,13 +133,15 @@ func main() {
 	// ── Download ────────────────────────────────────────────────────────
 	case "download", "dl":
 		if len(os.Args) < 4 {
-			fmt.Println("Usage: docscan-cli download <document-id> <save-path>")
+			fmt.Println("Usage: docscan-cli download <document-id> <save-path> [--resume]")
 			os.Exit(1)
 		}
 		docID, savePath := os.Args[2], os.Args[3]
+		resume := len(os.Args) >= 5 && os.Args[4] == "--resume"
 
 		fmt.Printf("Downloading: %s → %s\n", docID, savePath)
-		if err := client.DownloadOriginal(docID, savePath); err != nil {
+		opts := docscan.DownloadOptions{Resume: resume, VerifyDigest: true}
+		if err := client.DownloadOriginalWithOptions(docID, savePath, opts); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -150,6 +178,50 @@ func main() {
 		}
 		fmt.Println(text)
 
+	// ── Batch ───────────────────────────────────────────────────────────
+	case "batch":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: docscan-cli batch <dir> [--include=<glob>] [--exclude=<glob>] [--max-depth=N] [--concurrency=N] [--fail-fast] [--manifest=<path>]")
+			os.Exit(1)
+		}
+		dir := os.Args[2]
+		opts, manifestPath := parseBatchArgs(os.Args[3:])
+
+		fmt.Printf("Batch uploading: %s\n", dir)
+		fmt.Println("─────────────────────────────────────────")
+
+		total := 0
+		opts.ProgressFunc = func(item docscan.BatchItem, done, n int) {
+			total = n
+			status := "✓"
+			if item.Err != nil {
+				status = "✗"
+			}
+			fmt.Printf("  [%d/%d] %s %s\n", done, n, status, item.Path)
+		}
+
+		result, err := client.UploadDirectory(dir, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("─────────────────────────────────────────")
+		fmt.Printf("Done: %d succeeded, %d failed, %d total, %s\n",
+			result.Succeeded, result.Failed, total, result.Duration.Round(time.Millisecond))
+
+		if manifestPath != "" {
+			if err := writeBatchManifest(manifestPath, result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing manifest: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Manifest written to: %s\n", manifestPath)
+		}
+
+		if result.Failed > 0 {
+			os.Exit(1)
+		}
+
 	// ── Delete ──────────────────────────────────────────────────────────
 	case "delete", "rm":
 		if len(os.Args) < 3 {
@@ -192,9 +264,10 @@ func printUsage() {
 DocScan CLI — Document Upload & OCR Client
 
 Commands:
-  upload  <file>                 Upload a document (OCR auto-applied)
+  upload  <file> [--no-progress] Upload a document (OCR auto-applied)
+  batch   <dir>  [flags]         Recursively upload a directory
   list                           List all documents
-  download <id> <save-path>      Download original file
+  download <id> <save-path> [--resume]  Download original file
   text <id> [save-path]          Get/save extracted text
   delete <id>                    Delete a document
   health                         Check service health
@@ -211,6 +284,61 @@ Examples:
   docscan-cli delete 1706900000000-receipt.jpg`)
 }
 
+// printUploadProgress renders a percent+speed line to stderr, e.g.
+// "   42.3%  (12.1 MB / 28.6 MB)  3.4 MB/s". It overwrites the previous
+// line with \r so the CLI shows a single updating progress indicator.
+func printUploadProgress(sent, total int64, elapsed time.Duration) {
+	pct := 100.0
+	if total > 0 {
+		pct = float64(sent) / float64(total) * 100
+	}
+	speed := float64(sent) / 1024 / 1024
+	if elapsed > 0 {
+		speed = float64(sent) / 1024 / 1024 / elapsed.Seconds()
+	}
+	fmt.Fprintf(os.Stderr, "\r  %5.1f%%  (%.1f MB / %.1f MB)  %.1f MB/s",
+		pct, float64(sent)/1024/1024, float64(total)/1024/1024, speed)
+}
+
+// parseBatchArgs parses the flags accepted by the "batch" subcommand,
+// returning the resulting BatchOptions and an optional manifest path.
+func parseBatchArgs(args []string) (docscan.BatchOptions, string) {
+	var opts docscan.BatchOptions
+	manifestPath := ""
+
+	for _, arg := range args {
+		switch {
+		case arg == "--fail-fast":
+			opts.FailFast = true
+		case strings.HasPrefix(arg, "--include="):
+			opts.Include = append(opts.Include, strings.Split(strings.TrimPrefix(arg, "--include="), ",")...)
+		case strings.HasPrefix(arg, "--exclude="):
+			opts.Exclude = append(opts.Exclude, strings.Split(strings.TrimPrefix(arg, "--exclude="), ",")...)
+		case strings.HasPrefix(arg, "--max-depth="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(arg, "--max-depth=")); err == nil {
+				opts.MaxDepth = n
+			}
+		case strings.HasPrefix(arg, "--concurrency="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(arg, "--concurrency=")); err == nil {
+				opts.Concurrency = n
+			}
+		case strings.HasPrefix(arg, "--manifest="):
+			manifestPath = strings.TrimPrefix(arg, "--manifest=")
+		}
+	}
+
+	return opts, manifestPath
+}
+
+// writeBatchManifest writes result as indented JSON to path.
+func writeBatchManifest(path string, result *docscan.BatchResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
 func envOr(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -0,0 +1,235 @@
+// ═══════════════════════════════════════════════════════════════════════════════
+// Resumable Downloads
+// ═══════════════════════════════════════════════════════════════════════════════
+package docscan
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DownloadOptions configures DownloadOriginalWithOptions and
+// DownloadTextWithOptions.
+type DownloadOptions struct {
+	// Context governs cancellation of the download.
+	Context context.Context
+
+	// Resume, if true and savePath already exists, issues a HEAD first
+	// and sends a Range request for the remaining bytes, appending to
+	// the existing file on a 206 response. Falls back to a full
+	// re-download if the server doesn't honor Range.
+	Resume bool
+
+	// VerifyDigest, if true, hashes the downloaded bytes with SHA-256
+	// and compares against a server-advertised digest (from a
+	// Docker-Content-Digest, Content-Digest, or ETag header), deleting
+	// the file and returning an error on mismatch. Has no effect if the
+	// server doesn't advertise a digest in a format we understand.
+	VerifyDigest bool
+
+	// ProgressFunc, if set, is invoked periodically with the cumulative
+	// bytes received (including any bytes already on disk when
+	// resuming) and the total file size.
+	ProgressFunc func(bytesReceived, totalBytes int64)
+}
+
+// downloadFile fetches urlPath and writes it to savePath, optionally
+// resuming a partial download and verifying its digest per opts.
+func (c *Client) downloadFile(urlPath, savePath string, opts DownloadOptions) error {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var existing int64
+	if opts.Resume {
+		if info, err := os.Stat(savePath); err == nil {
+			existing = info.Size()
+		}
+	}
+
+	var totalSize int64 = -1
+	var digest *digestInfo
+	if opts.Resume || opts.VerifyDigest {
+		totalSize, digest = c.headFile(ctx, urlPath)
+	}
+	if !opts.VerifyDigest {
+		digest = nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+urlPath, nil)
+	if err != nil {
+		return err
+	}
+	if err := c.setHeaders(req); err != nil {
+		return fmt.Errorf("failed to authenticate request: %w", err)
+	}
+
+	resuming := opts.Resume && existing > 0 && (totalSize < 0 || existing < totalSize)
+	if resuming {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", existing))
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return c.parseError(resp)
+	}
+
+	appending := resuming && resp.StatusCode == http.StatusPartialContent
+	flags := os.O_CREATE | os.O_WRONLY
+	resumeFrom := int64(0)
+	if appending {
+		flags |= os.O_APPEND
+		resumeFrom = existing
+	} else {
+		flags |= os.O_TRUNC
+	}
+
+	out, err := os.OpenFile(savePath, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer out.Close()
+
+	if digest == nil && opts.VerifyDigest {
+		digest = parseDigestHeader(resp.Header)
+	}
+
+	var hasher hash.Hash
+	var dst io.Writer = out
+	if digest != nil {
+		hasher = sha256.New()
+		if appending {
+			if err := seedHash(hasher, savePath, resumeFrom); err != nil {
+				return fmt.Errorf("failed to re-read partial file for digest verification: %w", err)
+			}
+		}
+		dst = io.MultiWriter(out, hasher)
+	}
+
+	total := totalSize
+	if total <= 0 {
+		total = resumeFrom + resp.ContentLength
+	}
+
+	var src io.Reader = resp.Body
+	if opts.ProgressFunc != nil {
+		src = &progressReader{r: resp.Body, ctx: ctx, total: total, sent: resumeFrom, fn: opts.ProgressFunc}
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if hasher != nil {
+		sum := hex.EncodeToString(hasher.Sum(nil))
+		if !strings.EqualFold(sum, digest.hex) {
+			out.Close()
+			os.Remove(savePath)
+			return fmt.Errorf("digest mismatch for %s: expected %s, got %s", savePath, digest.hex, sum)
+		}
+	}
+
+	return nil
+}
+
+// headFile issues a HEAD request and returns the advertised content
+// length (-1 if unknown) and digest (nil if none understood). HEAD
+// failures are non-fatal — some gateways don't support it: downloadFile
+// then still sends a Range request without a known total size, and looks
+// for a digest in the GET response's headers instead.
+func (c *Client) headFile(ctx context.Context, urlPath string) (int64, *digestInfo) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.BaseURL+urlPath, nil)
+	if err != nil {
+		return -1, nil
+	}
+	if err := c.setHeaders(req); err != nil {
+		return -1, nil
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return -1, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return -1, nil
+	}
+
+	size := resp.ContentLength
+	if size <= 0 {
+		if n, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil {
+			size = n
+		} else {
+			size = -1
+		}
+	}
+
+	return size, parseDigestHeader(resp.Header)
+}
+
+// digestInfo is a server-advertised SHA-256 digest to verify a download
+// against. Other algorithms are not currently supported.
+type digestInfo struct {
+	hex string
+}
+
+// parseDigestHeader looks for a SHA-256 digest in, in priority order, a
+// Docker-Content-Digest header (e.g. "sha256:<hex>"), a Content-Digest
+// header per RFC 9530 (e.g. "sha-256=:<base64>:"), or an ETag that looks
+// like a bare SHA-256 hex digest. Returns nil if none match.
+func parseDigestHeader(h http.Header) *digestInfo {
+	if v := h.Get("Docker-Content-Digest"); v != "" {
+		if algo, hexDigest, ok := strings.Cut(v, ":"); ok && strings.EqualFold(algo, "sha256") {
+			return &digestInfo{hex: strings.ToLower(hexDigest)}
+		}
+	}
+
+	if v := h.Get("Content-Digest"); v != "" {
+		if algo, b64, ok := strings.Cut(v, "="); ok && strings.EqualFold(strings.TrimSpace(algo), "sha-256") {
+			raw, err := base64.StdEncoding.DecodeString(strings.Trim(strings.TrimSpace(b64), ":"))
+			if err == nil {
+				return &digestInfo{hex: hex.EncodeToString(raw)}
+			}
+		}
+	}
+
+	if v := strings.Trim(h.Get("ETag"), `"`); len(v) == sha256.Size*2 {
+		if _, err := hex.DecodeString(v); err == nil {
+			return &digestInfo{hex: strings.ToLower(v)}
+		}
+	}
+
+	return nil
+}
+
+// seedHash feeds the first n bytes of the file at path into h, so a
+// resumed download's digest is computed over the whole file rather than
+// just the newly-downloaded tail.
+func seedHash(h hash.Hash, path string, n int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.CopyN(h, f, n); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+// ═══════════════════════════════════════════════════════════════════════════════
+// Event Subscription (SSE)
+// ═══════════════════════════════════════════════════════════════════════════════
+package docscan
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// EventType identifies the kind of event emitted by SubscribeEvents.
+type EventType string
+
+const (
+	EventDocumentUploaded EventType = "document.uploaded"
+	EventOCRStarted       EventType = "ocr.started"
+	EventOCRCompleted     EventType = "ocr.completed"
+	EventOCRFailed        EventType = "ocr.failed"
+	EventDocumentDeleted  EventType = "document.deleted"
+)
+
+// Event is a single message from the /v1/events SSE stream.
+type Event struct {
+	Type       EventType       `json:"type"`
+	DocumentID string          `json:"documentId"`
+	Timestamp  time.Time       `json:"timestamp"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// EventFilter narrows the subscription requested from the server. An
+// empty EventFilter subscribes to every event for every document.
+type EventFilter struct {
+	Types      []EventType
+	DocumentID string
+}
+
+func (f EventFilter) queryString() string {
+	q := url.Values{}
+	for _, t := range f.Types {
+		q.Add("type", string(t))
+	}
+	if f.DocumentID != "" {
+		q.Set("documentId", f.DocumentID)
+	}
+	return q.Encode()
+}
+
+const eventStreamMaxBackoff = 30 * time.Second
+
+// SubscribeEvents connects to /v1/events over Server-Sent Events and
+// returns a channel of typed Events for document.uploaded, ocr.started,
+// ocr.completed, ocr.failed, and document.deleted. The initial connection
+// is established synchronously, so a nil error guarantees the stream is
+// already open before SubscribeEvents returns — callers that upload
+// immediately afterward won't race an in-flight handshake and miss an
+// early event. Once connected, the subscription reconnects transparently
+// with exponential backoff on network errors, resuming via Last-Event-ID,
+// and the channel is closed once ctx is done.
+//
+//	events, err := client.SubscribeEvents(ctx, docscan.EventFilter{DocumentID: docID})
+//	for ev := range events {
+//	    fmt.Println(ev.Type, ev.DocumentID)
+//	}
+func (c *Client) SubscribeEvents(ctx context.Context, filter EventFilter) (<-chan Event, error) {
+	resp, err := c.openEventStream(ctx, filter, "")
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event)
+	go c.runEventStream(ctx, filter, events, resp)
+	return events, nil
+}
+
+// UploadAndWait uploads path and blocks on the event stream until the
+// server reports OCR completion (or failure) for it, or timeout elapses.
+// Useful for batch workflows where synchronous OCR can exceed the HTTP
+// client's timeout and callers would otherwise have to poll
+// ListDocuments.
+func (c *Client) UploadAndWait(path string, timeout time.Duration) (*UploadResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	events, err := c.SubscribeEvents(ctx, EventFilter{
+		Types: []EventType{EventOCRCompleted, EventOCRFailed},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.UploadDocumentWithOptions(path, UploadOptions{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return result, fmt.Errorf("event stream closed before OCR completed for %s", result.Document.ID)
+			}
+			if ev.DocumentID != result.Document.ID {
+				continue
+			}
+			if ev.Type == EventOCRFailed {
+				return result, fmt.Errorf("OCR failed for document %s", result.Document.ID)
+			}
+			return result, nil
+		case <-ctx.Done():
+			return result, fmt.Errorf("timed out waiting for OCR to complete for %s: %w", result.Document.ID, ctx.Err())
+		}
+	}
+}
+
+// runEventStream drives the reconnect loop. first is an already-open
+// response from SubscribeEvents' synchronous initial connection; it is
+// consumed before any further (re)connect attempts.
+func (c *Client) runEventStream(ctx context.Context, filter EventFilter, events chan<- Event, first *http.Response) {
+	defer close(events)
+
+	lastEventID := ""
+	attempt := 0
+	resp := first
+
+	for {
+		if ctx.Err() != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return
+		}
+
+		if resp == nil {
+			var err error
+			resp, err = c.openEventStream(ctx, filter, lastEventID)
+			if err != nil {
+				if ctx.Err() != nil || !sleepBackoff(ctx, attempt) {
+					return
+				}
+				attempt++
+				continue
+			}
+		}
+
+		attempt = 0
+		id, streamErr := readEventStream(ctx, resp.Body, events)
+		resp.Body.Close()
+		resp = nil
+		if id != "" {
+			lastEventID = id
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if streamErr != nil {
+			if !sleepBackoff(ctx, attempt) {
+				return
+			}
+			attempt++
+		}
+	}
+}
+
+func (c *Client) openEventStream(ctx context.Context, filter EventFilter, lastEventID string) (*http.Response, error) {
+	path := "/v1/events"
+	if qs := filter.queryString(); qs != "" {
+		path += "?" + qs
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.setHeaders(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, c.parseError(resp)
+	}
+	return resp, nil
+}
+
+// readEventStream parses SSE "event:"/"data:"/"id:" framing from r,
+// sending a typed Event for each complete frame until r is exhausted,
+// ctx is done, or a read error occurs. It returns the last "id:" seen
+// (for Last-Event-ID resumption on reconnect) and any read error.
+func readEventStream(ctx context.Context, r io.Reader, events chan<- Event) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var eventType, data, id string
+
+	flush := func() bool {
+		if data == "" {
+			return true
+		}
+		ev := Event{Type: EventType(eventType), Timestamp: time.Now()}
+		if err := json.Unmarshal([]byte(data), &ev); err != nil {
+			ev.Payload = json.RawMessage(data)
+		}
+		if ev.Type == "" {
+			ev.Type = EventType(eventType)
+		}
+		eventType, data = "", ""
+
+		select {
+		case events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if !flush() {
+				return id, ctx.Err()
+			}
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data != "" {
+				data += "\n"
+			}
+			data += strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		}
+	}
+	flush()
+
+	return id, scanner.Err()
+}
+
+// sleepBackoff waits an exponential, jittered backoff for the given
+// zero-based attempt (capped at eventStreamMaxBackoff), returning false
+// if ctx is done first.
+func sleepBackoff(ctx context.Context, attempt int) bool {
+	d := jitteredBackoff(attempt, 500*time.Millisecond, eventStreamMaxBackoff)
+
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}